@@ -0,0 +1,58 @@
+package activity
+
+import "context"
+
+// ActivityInfo identifies the activity an ActivityInterceptor hook is being
+// called for.
+type ActivityInfo struct {
+	WorkflowID string
+	ActivityID string
+	TaskToken  string
+}
+
+// ActivityOutcome is how an activity was ultimately reported to the
+// workflow service.
+type ActivityOutcome string
+
+// The possible outcomes an ActivityInterceptor's OnComplete is called with.
+const (
+	ActivityOutcomeSucceeded ActivityOutcome = "succeeded"
+	ActivityOutcomeFailed    ActivityOutcome = "failed"
+	ActivityOutcomeCancelled ActivityOutcome = "cancelled"
+)
+
+// ActivityInterceptor observes an activity's execution without Worker
+// needing to know about any particular concern, such as logging, metrics,
+// or tracing. Worker calls every configured interceptor's hooks in order at
+// the corresponding point in Do, the same pattern Cadence exposes as
+// interceptors/workflow_interceptor.go.
+type ActivityInterceptor interface {
+	// BeforeExecute is called just before the activity function runs.
+	BeforeExecute(ctx context.Context, info ActivityInfo)
+	// AfterExecute is called with the activity function's result and
+	// error as soon as it returns, before the outcome is reported to the
+	// workflow service.
+	AfterExecute(ctx context.Context, info ActivityInfo, result interface{}, err error)
+	// OnHeartbeat is called after each heartbeat the worker successfully
+	// sends, reporting whether cancellation has been requested.
+	OnHeartbeat(ctx context.Context, info ActivityInfo, cancelled bool)
+	// OnComplete is called once the activity has been reported to the
+	// workflow service, with the outcome it was reported as.
+	OnComplete(ctx context.Context, info ActivityInfo, outcome ActivityOutcome)
+}
+
+// NopInterceptor is an ActivityInterceptor whose hooks all do nothing.
+// Embed it to implement only the hooks a particular interceptor needs.
+type NopInterceptor struct{}
+
+// BeforeExecute does nothing.
+func (NopInterceptor) BeforeExecute(context.Context, ActivityInfo) {}
+
+// AfterExecute does nothing.
+func (NopInterceptor) AfterExecute(context.Context, ActivityInfo, interface{}, error) {}
+
+// OnHeartbeat does nothing.
+func (NopInterceptor) OnHeartbeat(context.Context, ActivityInfo, bool) {}
+
+// OnComplete does nothing.
+func (NopInterceptor) OnComplete(context.Context, ActivityInfo, ActivityOutcome) {}
@@ -0,0 +1,59 @@
+package activity
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/3dsim/workflow-goclient/workflow/workflowfakes"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingInterceptor struct {
+	NopInterceptor
+
+	mu       sync.Mutex
+	before   []ActivityInfo
+	after    []error
+	outcomes []ActivityOutcome
+}
+
+func (r *recordingInterceptor) BeforeExecute(ctx context.Context, info ActivityInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.before = append(r.before, info)
+}
+
+func (r *recordingInterceptor) AfterExecute(ctx context.Context, info ActivityInfo, result interface{}, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.after = append(r.after, err)
+}
+
+func (r *recordingInterceptor) OnComplete(ctx context.Context, info ActivityInfo, outcome ActivityOutcome) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.outcomes = append(r.outcomes, outcome)
+}
+
+func TestDoCallsInterceptorHooksInOrderAroundTheActivityFunction(t *testing.T) {
+	// arrange
+	fakeWorkflowClient := &workflowfakes.FakeClient{}
+	interceptor := &recordingInterceptor{}
+	worker := &Worker{WorkflowClient: fakeWorkflowClient, Logger: logger, Interceptors: []ActivityInterceptor{interceptor}}
+	activityID := "activity id"
+	workflowID := "workflow id"
+	taskToken := "token"
+
+	// act
+	worker.Do(context.Background(), workflowID, activityID, taskToken, func(context.Context, chan<- int, chan<- interface{}) (interface{}, error) {
+		return "the result", nil
+	})
+
+	// assert
+	interceptor.mu.Lock()
+	defer interceptor.mu.Unlock()
+	assert.Equal(t, []ActivityInfo{{WorkflowID: workflowID, ActivityID: activityID, TaskToken: taskToken}}, interceptor.before, "Expected BeforeExecute to be called once with the activity's info")
+	assert.Equal(t, []error{nil}, interceptor.after, "Expected AfterExecute to be called once with the function's error")
+	assert.Equal(t, []ActivityOutcome{ActivityOutcomeSucceeded}, interceptor.outcomes, "Expected OnComplete to be called once with the succeeded outcome")
+}
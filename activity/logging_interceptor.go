@@ -0,0 +1,41 @@
+package activity
+
+import (
+	"context"
+
+	log "github.com/inconshreveable/log15"
+)
+
+// LoggingInterceptor logs an activity's lifecycle with its workflowID,
+// activityID, and taskToken attached to every line so they can be
+// correlated, replacing the worker's previously hardcoded logger.Info
+// calls.
+type LoggingInterceptor struct {
+	NopInterceptor
+	Logger log.Logger
+}
+
+// BeforeExecute logs that the activity function is about to run.
+func (i *LoggingInterceptor) BeforeExecute(ctx context.Context, info ActivityInfo) {
+	i.Logger.Info("Activity starting", "workflowID", info.WorkflowID, "activityID", info.ActivityID, "taskToken", info.TaskToken)
+}
+
+// AfterExecute logs whether the activity function returned an error.
+func (i *LoggingInterceptor) AfterExecute(ctx context.Context, info ActivityInfo, result interface{}, err error) {
+	if err != nil {
+		i.Logger.Info("Activity function returned an error", "workflowID", info.WorkflowID, "activityID", info.ActivityID, "taskToken", info.TaskToken, "error", err)
+		return
+	}
+	i.Logger.Info("Activity function returned successfully", "workflowID", info.WorkflowID, "activityID", info.ActivityID, "taskToken", info.TaskToken)
+}
+
+// OnHeartbeat logs each heartbeat at debug level.
+func (i *LoggingInterceptor) OnHeartbeat(ctx context.Context, info ActivityInfo, cancelled bool) {
+	i.Logger.Debug("Activity heartbeat", "workflowID", info.WorkflowID, "activityID", info.ActivityID, "taskToken", info.TaskToken, "cancelled", cancelled)
+}
+
+// OnComplete logs the outcome the activity was reported to the workflow
+// service as.
+func (i *LoggingInterceptor) OnComplete(ctx context.Context, info ActivityInfo, outcome ActivityOutcome) {
+	i.Logger.Info("Activity completed", "workflowID", info.WorkflowID, "activityID", info.ActivityID, "taskToken", info.TaskToken, "outcome", outcome)
+}
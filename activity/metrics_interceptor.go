@@ -0,0 +1,61 @@
+package activity
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/uber-go/tally"
+)
+
+// MetricsInterceptor emits execution latency, heartbeat counts, and
+// success/failure/cancellation counters to a tally.Scope, the metrics
+// facade Cadence/Temporal workers use.
+type MetricsInterceptor struct {
+	NopInterceptor
+	Scope tally.Scope
+
+	mu         sync.Mutex
+	startTimes map[string]time.Time
+}
+
+// BeforeExecute records the activity's start time so OnComplete can report
+// its execution latency.
+func (i *MetricsInterceptor) BeforeExecute(ctx context.Context, info ActivityInfo) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.startTimes == nil {
+		i.startTimes = make(map[string]time.Time)
+	}
+	i.startTimes[info.ActivityID] = time.Now()
+}
+
+// OnHeartbeat increments the heartbeat counter, plus a cancellation counter
+// the first time cancellation is observed to have been requested.
+func (i *MetricsInterceptor) OnHeartbeat(ctx context.Context, info ActivityInfo, cancelled bool) {
+	i.Scope.Counter("activity.heartbeat").Inc(1)
+	if cancelled {
+		i.Scope.Counter("activity.cancellation_requested").Inc(1)
+	}
+}
+
+// OnComplete reports the activity's execution latency and increments the
+// counter matching its outcome.
+func (i *MetricsInterceptor) OnComplete(ctx context.Context, info ActivityInfo, outcome ActivityOutcome) {
+	i.mu.Lock()
+	start, ok := i.startTimes[info.ActivityID]
+	delete(i.startTimes, info.ActivityID)
+	i.mu.Unlock()
+	if ok {
+		i.Scope.Timer("activity.execution_latency").Record(time.Since(start))
+	}
+
+	switch outcome {
+	case ActivityOutcomeSucceeded:
+		i.Scope.Counter("activity.succeeded").Inc(1)
+	case ActivityOutcomeFailed:
+		i.Scope.Counter("activity.failed").Inc(1)
+	case ActivityOutcomeCancelled:
+		i.Scope.Counter("activity.cancelled").Inc(1)
+	}
+}
@@ -0,0 +1,261 @@
+package activity
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/3dsim/workflow-goclient/encoded"
+	"github.com/3dsim/workflow-goclient/workflow"
+	log "github.com/inconshreveable/log15"
+)
+
+const (
+	// defaultMaxConcurrentActivities is used when
+	// WorkerPool.MaxConcurrentActivities is not set.
+	defaultMaxConcurrentActivities = 100
+	// defaultShutdownTimeout is used when WorkerPool.ShutdownTimeout is
+	// not set.
+	defaultShutdownTimeout = 30 * time.Second
+)
+
+// Task identifies a single activity invocation for a WorkerPool to run.
+type Task struct {
+	ActivityType string
+	WorkflowID   string
+	ActivityID   string
+	TaskToken    string
+}
+
+// WorkerPool runs a registry of ActivityFuncs across a bounded set of
+// goroutines, gating concurrency both globally and per activity type, and
+// lets callers run the client as a long-lived worker process instead of
+// needing external orchestration around a single Worker.
+type WorkerPool struct {
+	WorkflowClient workflow.Client
+	Logger         log.Logger
+
+	// HeartbeatInterval, CancellationTimeout, RetryPolicy, and
+	// DataConverter configure every Worker the pool runs; see Worker for
+	// their meaning and defaults.
+	HeartbeatInterval   time.Duration
+	CancellationTimeout time.Duration
+	RetryPolicy         *RetryPolicy
+	DataConverter       encoded.DataConverter
+
+	// MaxConcurrentActivities bounds how many activities run at once
+	// across all types. Defaults to defaultMaxConcurrentActivities.
+	MaxConcurrentActivities int
+	// MaxConcurrentByActivityType caps concurrency for a specific
+	// activity type. A type absent from this map is bounded only by
+	// MaxConcurrentActivities.
+	MaxConcurrentByActivityType map[string]int
+	// ShutdownTimeout bounds how long Stop waits for in-flight
+	// activities to finish before cancelling them and returning anyway.
+	// Defaults to defaultShutdownTimeout.
+	ShutdownTimeout time.Duration
+
+	mu           sync.Mutex
+	activities   map[string]ActivityFunc
+	globalTokens chan struct{}
+	typeTokens   map[string]chan struct{}
+	tasks        chan Task
+	cancels      map[string]context.CancelFunc
+	running      sync.WaitGroup
+	started      bool
+	stopped      chan struct{}
+}
+
+// Register associates an activity type with the function that runs it. It
+// must be called before Start.
+func (p *WorkerPool) Register(activityType string, fn ActivityFunc) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.activities == nil {
+		p.activities = make(map[string]ActivityFunc)
+	}
+	p.activities[activityType] = fn
+}
+
+// Start begins dispatching tasks submitted via Submit until ctx is done or
+// Stop is called. It returns an error if the pool has already been started.
+func (p *WorkerPool) Start(ctx context.Context) error {
+	p.mu.Lock()
+	if p.started {
+		p.mu.Unlock()
+		return fmt.Errorf("worker pool already started")
+	}
+	p.started = true
+
+	maxConcurrent := p.MaxConcurrentActivities
+	if maxConcurrent <= 0 {
+		maxConcurrent = defaultMaxConcurrentActivities
+	}
+	p.globalTokens = make(chan struct{}, maxConcurrent)
+	p.typeTokens = make(map[string]chan struct{}, len(p.MaxConcurrentByActivityType))
+	for activityType, maxForType := range p.MaxConcurrentByActivityType {
+		if maxForType > 0 {
+			p.typeTokens[activityType] = make(chan struct{}, maxForType)
+		}
+	}
+	p.tasks = make(chan Task)
+	p.cancels = make(map[string]context.CancelFunc)
+	p.stopped = make(chan struct{})
+	p.mu.Unlock()
+
+	go p.dispatch(ctx)
+	return nil
+}
+
+// Submit hands task to the pool's dispatcher, blocking until the dispatcher
+// accepts it, ctx is done, or the pool has been stopped. It does not wait for
+// a concurrency slot: an accepted task waits for one on its own goroutine
+// (see run).
+func (p *WorkerPool) Submit(ctx context.Context, task Task) error {
+	// The dispatcher keeps receiving after Stop, and select picks at random
+	// among ready cases, so a stopped pool is checked for first.
+	select {
+	case <-p.stopped:
+		return fmt.Errorf("worker pool is stopped")
+	default:
+	}
+	select {
+	case p.tasks <- task:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-p.stopped:
+		return fmt.Errorf("worker pool is stopped")
+	}
+}
+
+func (p *WorkerPool) dispatch(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case task := <-p.tasks:
+			go p.run(ctx, task)
+		}
+	}
+}
+
+// run waits for both the global and, if configured, the activity type's
+// concurrency token to become available, then starts the activity in its
+// own goroutine. task is counted in p.running from the moment run is
+// called - before it waits for a token - so Stop's drain accounts for it
+// even while it is still queued. If the pool is stopped while run is
+// waiting for a token, it abandons the task without starting the activity,
+// so a task can never begin running after Stop has returned.
+func (p *WorkerPool) run(ctx context.Context, task Task) {
+	p.mu.Lock()
+	fn, ok := p.activities[task.ActivityType]
+	typeTokens := p.typeTokens[task.ActivityType]
+	p.mu.Unlock()
+	if !ok {
+		p.Logger.Error("No activity registered for type, skipping task", "activityType", task.ActivityType, "activityID", task.ActivityID)
+		return
+	}
+
+	p.running.Add(1)
+
+	select {
+	case p.globalTokens <- struct{}{}:
+	case <-p.stopped:
+		p.running.Done()
+		p.Logger.Info("Worker pool stopped before a concurrency slot was available, dropping task", "activityID", task.ActivityID)
+		return
+	}
+	if typeTokens != nil {
+		select {
+		case typeTokens <- struct{}{}:
+		case <-p.stopped:
+			<-p.globalTokens
+			p.running.Done()
+			p.Logger.Info("Worker pool stopped before a concurrency slot was available, dropping task", "activityID", task.ActivityID)
+			return
+		}
+	}
+	// select picks at random when a token and the stop signal are both
+	// ready, so check once more before starting the activity.
+	select {
+	case <-p.stopped:
+		<-p.globalTokens
+		if typeTokens != nil {
+			<-typeTokens
+		}
+		p.running.Done()
+		p.Logger.Info("Worker pool stopped before the task started, dropping task", "activityID", task.ActivityID)
+		return
+	default:
+	}
+
+	activityCtx, cancel := context.WithCancel(ctx)
+	p.mu.Lock()
+	p.cancels[task.ActivityID] = cancel
+	p.mu.Unlock()
+
+	go func() {
+		defer p.running.Done()
+		defer func() {
+			<-p.globalTokens
+			if typeTokens != nil {
+				<-typeTokens
+			}
+			p.mu.Lock()
+			delete(p.cancels, task.ActivityID)
+			p.mu.Unlock()
+			cancel()
+		}()
+
+		worker := &Worker{
+			WorkflowClient:      p.WorkflowClient,
+			Logger:              p.Logger,
+			HeartbeatInterval:   p.HeartbeatInterval,
+			CancellationTimeout: p.CancellationTimeout,
+			RetryPolicy:         p.RetryPolicy,
+			DataConverter:       p.DataConverter,
+		}
+		worker.Do(activityCtx, task.WorkflowID, task.ActivityID, task.TaskToken, fn)
+	}()
+}
+
+// Stop stops accepting new tasks and waits up to ShutdownTimeout for
+// in-flight activities to finish. If the timeout elapses first, it cancels
+// every in-flight activity's context and returns an error; it also returns
+// early if ctx is done first.
+func (p *WorkerPool) Stop(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.started {
+		p.mu.Unlock()
+		return nil
+	}
+	close(p.stopped)
+	p.mu.Unlock()
+
+	shutdownTimeout := p.ShutdownTimeout
+	if shutdownTimeout <= 0 {
+		shutdownTimeout = defaultShutdownTimeout
+	}
+
+	drained := make(chan struct{})
+	go func() {
+		p.running.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return nil
+	case <-time.After(shutdownTimeout):
+		p.mu.Lock()
+		for _, cancel := range p.cancels {
+			cancel()
+		}
+		p.mu.Unlock()
+		return fmt.Errorf("timed out after %s waiting for activities to drain", shutdownTimeout)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,144 @@
+package activity
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/3dsim/workflow-goclient/workflow/workflowfakes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkerPoolRunsRegisteredActivityForSubmittedTask(t *testing.T) {
+	// arrange
+	fakeWorkflowClient := &workflowfakes.FakeClient{}
+	pool := &WorkerPool{WorkflowClient: fakeWorkflowClient, Logger: logger}
+	pool.Register("scan", func(ctx context.Context, percentCompleteChan chan<- int, detailsChan chan<- interface{}) (interface{}, error) {
+		return "done", nil
+	})
+	ctx, cancelPool := context.WithCancel(context.Background())
+	defer cancelPool()
+	assert.NoError(t, pool.Start(ctx), "Expected Start to succeed")
+
+	// act
+	err := pool.Submit(ctx, Task{ActivityType: "scan", WorkflowID: "workflow id", ActivityID: "activity id", TaskToken: "token"})
+
+	// assert
+	assert.NoError(t, err, "Expected Submit to succeed")
+	assert.Eventually(t, func() bool {
+		return fakeWorkflowClient.CompleteSuccessfulActivityCallCount() == 1
+	}, time.Second, time.Millisecond, "Expected the registered activity to run and complete")
+}
+
+func TestWorkerPoolLimitsConcurrentActivitiesToMaxConcurrentActivities(t *testing.T) {
+	// arrange
+	fakeWorkflowClient := &workflowfakes.FakeClient{}
+	pool := &WorkerPool{WorkflowClient: fakeWorkflowClient, Logger: logger, MaxConcurrentActivities: 2}
+	var mu sync.Mutex
+	current, max := 0, 0
+	release := make(chan struct{})
+	pool.Register("scan", func(ctx context.Context, percentCompleteChan chan<- int, detailsChan chan<- interface{}) (interface{}, error) {
+		mu.Lock()
+		current++
+		if current > max {
+			max = current
+		}
+		mu.Unlock()
+
+		<-release
+
+		mu.Lock()
+		current--
+		mu.Unlock()
+		return nil, nil
+	})
+	ctx, cancelPool := context.WithCancel(context.Background())
+	defer cancelPool()
+	assert.NoError(t, pool.Start(ctx), "Expected Start to succeed")
+
+	// act
+	// Submit returns once the dispatcher accepts a task; with
+	// MaxConcurrentActivities less than the number of tasks, the later
+	// tasks wait inside the pool until an earlier activity releases its
+	// token.
+	for i := 0; i < 5; i++ {
+		activityID := fmt.Sprintf("activity-%d", i)
+		go func() {
+			err := pool.Submit(ctx, Task{ActivityType: "scan", WorkflowID: "workflow id", ActivityID: activityID, TaskToken: "token"})
+			assert.NoError(t, err, "Expected Submit to succeed")
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	// assert
+	assert.Eventually(t, func() bool {
+		return fakeWorkflowClient.CompleteSuccessfulActivityCallCount() == 5
+	}, time.Second, time.Millisecond, "Expected all submitted activities to eventually complete")
+	mu.Lock()
+	defer mu.Unlock()
+	assert.LessOrEqual(t, max, 2, "Expected no more than MaxConcurrentActivities activities running at once")
+}
+
+func TestWorkerPoolStopCancelsInFlightActivitiesAfterShutdownTimeout(t *testing.T) {
+	// arrange
+	fakeWorkflowClient := &workflowfakes.FakeClient{}
+	pool := &WorkerPool{WorkflowClient: fakeWorkflowClient, Logger: logger, ShutdownTimeout: 10 * time.Millisecond}
+	cancelled := make(chan struct{})
+	pool.Register("scan", func(ctx context.Context, percentCompleteChan chan<- int, detailsChan chan<- interface{}) (interface{}, error) {
+		<-ctx.Done()
+		close(cancelled)
+		return nil, ctx.Err()
+	})
+	ctx := context.Background()
+	assert.NoError(t, pool.Start(ctx), "Expected Start to succeed")
+	assert.NoError(t, pool.Submit(ctx, Task{ActivityType: "scan", WorkflowID: "workflow id", ActivityID: "activity id", TaskToken: "token"}), "Expected Submit to succeed")
+	time.Sleep(5 * time.Millisecond)
+
+	// act
+	err := pool.Stop(ctx)
+
+	// assert
+	assert.Error(t, err, "Expected Stop to report that it timed out waiting for the activity to drain")
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Error("Expected the in-flight activity's context to be cancelled")
+	}
+}
+
+func TestWorkerPoolStopAbortsTaskStillWaitingForAConcurrencySlot(t *testing.T) {
+	// arrange
+	fakeWorkflowClient := &workflowfakes.FakeClient{}
+	pool := &WorkerPool{WorkflowClient: fakeWorkflowClient, Logger: logger, MaxConcurrentActivities: 1, ShutdownTimeout: 10 * time.Millisecond}
+	var starts int32
+	pool.Register("scan", func(ctx context.Context, percentCompleteChan chan<- int, detailsChan chan<- interface{}) (interface{}, error) {
+		atomic.AddInt32(&starts, 1)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	ctx := context.Background()
+	assert.NoError(t, pool.Start(ctx), "Expected Start to succeed")
+	assert.NoError(t, pool.Submit(ctx, Task{ActivityType: "scan", WorkflowID: "workflow id", ActivityID: "first", TaskToken: "token"}), "Expected Submit to succeed")
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&starts) == 1
+	}, time.Second, time.Millisecond, "Expected the first task to claim the pool's only concurrency slot")
+
+	// act
+	// Submit a second task while the pool is saturated, so it is left
+	// waiting for a concurrency slot, then stop the pool before that slot
+	// frees up.
+	go func() {
+		pool.Submit(ctx, Task{ActivityType: "scan", WorkflowID: "workflow id", ActivityID: "second", TaskToken: "token"})
+	}()
+	time.Sleep(5 * time.Millisecond)
+	err := pool.Stop(ctx)
+
+	// assert
+	assert.Error(t, err, "Expected Stop to report that it timed out waiting for the in-flight activity to drain")
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&starts), "Expected the queued task to never start once Stop had returned")
+}
@@ -0,0 +1,115 @@
+package activity
+
+import (
+	"context"
+	"net"
+	"time"
+)
+
+// RetryPolicy controls how Worker retries calls to the workflow service
+// (heartbeats and activity completion) when they fail with a transient
+// error. It mirrors the retry policies Cadence/Temporal attach to their
+// internal service clients.
+type RetryPolicy struct {
+	// InitialInterval is the wait before the first retry.
+	InitialInterval time.Duration
+	// BackoffCoefficient is multiplied into the interval after each
+	// retry, e.g. 2.0 doubles the wait every attempt.
+	BackoffCoefficient float64
+	// MaxInterval caps the wait between retries regardless of how many
+	// attempts have elapsed.
+	MaxInterval time.Duration
+	// MaxAttempts is the maximum number of calls to make, including the
+	// first. Zero means unlimited attempts (bounded only by Expiration).
+	MaxAttempts int
+	// Expiration is the total time budget across all attempts. Zero
+	// means no time limit (bounded only by MaxAttempts).
+	Expiration time.Duration
+}
+
+// DefaultRetryPolicy returns the retry policy Worker uses when none is
+// configured.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		InitialInterval:    200 * time.Millisecond,
+		BackoffCoefficient: 2.0,
+		MaxInterval:        30 * time.Second,
+		MaxAttempts:        0,
+		Expiration:         time.Minute,
+	}
+}
+
+// nextInterval returns the wait before the given retry attempt (1-indexed),
+// capped at MaxInterval.
+func (p *RetryPolicy) nextInterval(attempt int) time.Duration {
+	interval := float64(p.InitialInterval)
+	for i := 1; i < attempt; i++ {
+		interval *= p.BackoffCoefficient
+		if time.Duration(interval) > p.MaxInterval {
+			return p.MaxInterval
+		}
+	}
+	return time.Duration(interval)
+}
+
+// transientCoder is implemented by errors from go-openapi generated clients
+// that carry an HTTP status code.
+type transientCoder interface {
+	Code() int
+}
+
+// IsTransientError classifies an error returned from the workflow service as
+// transient (worth retrying) or permanent. Network errors that report a
+// timeout or a temporary condition, and 5xx/429 responses, are transient;
+// everything else, including 4xx responses such as
+// NotFound, is treated as a permanent failure that should not be retried.
+func IsTransientError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if err == context.Canceled || err == context.DeadlineExceeded {
+		return false
+	}
+	if netErr, ok := err.(net.Error); ok {
+		return netErr.Timeout() || netErr.Temporary()
+	}
+	if coder, ok := err.(transientCoder); ok {
+		code := coder.Code()
+		return code == 429 || code >= 500
+	}
+	return false
+}
+
+// retryWithPolicy calls fn, retrying on transient errors according to
+// policy until it succeeds, a permanent error is returned, MaxAttempts is
+// exhausted, Expiration elapses, or ctx is cancelled.
+func retryWithPolicy(ctx context.Context, policy *RetryPolicy, fn func() error) error {
+	if policy == nil {
+		policy = DefaultRetryPolicy()
+	}
+	start := time.Now()
+	var attempt int
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		attempt++
+		err := fn()
+		if err == nil || !IsTransientError(err) {
+			return err
+		}
+		if policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts {
+			return err
+		}
+		if policy.Expiration > 0 && time.Since(start) >= policy.Expiration {
+			return err
+		}
+		timer := time.NewTimer(policy.nextInterval(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package activity
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeNetError struct {
+	timeout   bool
+	temporary bool
+}
+
+func (e fakeNetError) Error() string   { return "fake net error" }
+func (e fakeNetError) Timeout() bool   { return e.timeout }
+func (e fakeNetError) Temporary() bool { return e.temporary }
+
+type fakeCodedError struct {
+	code int
+}
+
+func (e fakeCodedError) Error() string { return "fake coded error" }
+func (e fakeCodedError) Code() int     { return e.code }
+
+func TestIsTransientErrorReturnsFalseForNilError(t *testing.T) {
+	assert.False(t, IsTransientError(nil), "Expected a nil error to not be transient")
+}
+
+func TestIsTransientErrorReturnsFalseForContextCancellationErrors(t *testing.T) {
+	assert.False(t, IsTransientError(context.Canceled), "Expected context.Canceled to not be transient")
+	assert.False(t, IsTransientError(context.DeadlineExceeded), "Expected context.DeadlineExceeded to not be transient")
+}
+
+func TestIsTransientErrorReturnsTrueForTimeoutOrTemporaryNetErrors(t *testing.T) {
+	assert.True(t, IsTransientError(fakeNetError{timeout: true}), "Expected a timeout net.Error to be transient")
+	assert.True(t, IsTransientError(fakeNetError{temporary: true}), "Expected a temporary net.Error to be transient")
+}
+
+func TestIsTransientErrorReturnsFalseForANetErrorThatIsNeitherTimeoutNorTemporary(t *testing.T) {
+	assert.False(t, IsTransientError(fakeNetError{}), "Expected a non-timeout, non-temporary net.Error to not be transient")
+}
+
+func TestIsTransientErrorReturnsTrueForTooManyRequestsAndServerErrorCodes(t *testing.T) {
+	assert.True(t, IsTransientError(fakeCodedError{code: 429}), "Expected a 429 to be transient")
+	assert.True(t, IsTransientError(fakeCodedError{code: 500}), "Expected a 500 to be transient")
+	assert.True(t, IsTransientError(fakeCodedError{code: 503}), "Expected a 503 to be transient")
+}
+
+func TestIsTransientErrorReturnsFalseForClientErrorCodes(t *testing.T) {
+	assert.False(t, IsTransientError(fakeCodedError{code: 404}), "Expected a 404 to not be transient")
+	assert.False(t, IsTransientError(fakeCodedError{code: 400}), "Expected a 400 to not be transient")
+}
+
+func TestIsTransientErrorReturnsFalseForAnUnrecognizedError(t *testing.T) {
+	assert.False(t, IsTransientError(errors.New("not found")), "Expected an error that can't be positively classified as transient to not be retried")
+}
+
+func TestNextIntervalAppliesBackoffCoefficientUpToMaxInterval(t *testing.T) {
+	policy := &RetryPolicy{
+		InitialInterval:    100 * time.Millisecond,
+		BackoffCoefficient: 2.0,
+		MaxInterval:        1 * time.Second,
+	}
+	assert.Equal(t, 100*time.Millisecond, policy.nextInterval(1), "Expected the first retry to wait InitialInterval")
+	assert.Equal(t, 200*time.Millisecond, policy.nextInterval(2), "Expected the second retry to double the interval")
+	assert.Equal(t, 400*time.Millisecond, policy.nextInterval(3), "Expected the third retry to double the interval again")
+	assert.Equal(t, 1*time.Second, policy.nextInterval(10), "Expected the interval to be capped at MaxInterval")
+}
+
+func TestRetryWithPolicyReturnsNilWithoutRetryingWhenFnSucceeds(t *testing.T) {
+	calls := 0
+	err := retryWithPolicy(context.Background(), nil, func() error {
+		calls++
+		return nil
+	})
+	assert.NoError(t, err, "Expected no error")
+	assert.Equal(t, 1, calls, "Expected fn to be called exactly once")
+}
+
+func TestRetryWithPolicyDoesNotRetryAPermanentError(t *testing.T) {
+	calls := 0
+	permanentErr := errors.New("not found")
+	err := retryWithPolicy(context.Background(), nil, func() error {
+		calls++
+		return permanentErr
+	})
+	assert.Equal(t, permanentErr, err, "Expected the permanent error to be returned")
+	assert.Equal(t, 1, calls, "Expected fn to be called exactly once")
+}
+
+func TestRetryWithPolicyRetriesATransientErrorUntilItSucceeds(t *testing.T) {
+	calls := 0
+	policy := &RetryPolicy{InitialInterval: time.Millisecond, BackoffCoefficient: 1.0, MaxInterval: time.Millisecond}
+	err := retryWithPolicy(context.Background(), policy, func() error {
+		calls++
+		if calls < 3 {
+			return fakeCodedError{code: 503}
+		}
+		return nil
+	})
+	assert.NoError(t, err, "Expected the call to eventually succeed")
+	assert.Equal(t, 3, calls, "Expected fn to be retried until it succeeded")
+}
+
+func TestRetryWithPolicyStopsAfterMaxAttempts(t *testing.T) {
+	calls := 0
+	transientErr := fakeCodedError{code: 503}
+	policy := &RetryPolicy{InitialInterval: time.Millisecond, BackoffCoefficient: 1.0, MaxInterval: time.Millisecond, MaxAttempts: 3}
+	err := retryWithPolicy(context.Background(), policy, func() error {
+		calls++
+		return transientErr
+	})
+	assert.Equal(t, transientErr, err, "Expected the last transient error to be returned")
+	assert.Equal(t, 3, calls, "Expected fn to be called MaxAttempts times")
+}
+
+func TestRetryWithPolicyStopsWhenCtxIsCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls := 0
+	err := retryWithPolicy(ctx, nil, func() error {
+		calls++
+		return fakeCodedError{code: 503}
+	})
+	assert.Equal(t, context.Canceled, err, "Expected a cancelled context to short-circuit before fn is called")
+	assert.Equal(t, 0, calls, "Expected fn to never be called once ctx was already cancelled")
+}
+
+func TestRetryWithPolicyStopsWaitingForTheNextRetryWhenCtxIsCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	policy := &RetryPolicy{InitialInterval: time.Hour, BackoffCoefficient: 1.0, MaxInterval: time.Hour}
+	transientErr := fakeCodedError{code: 503}
+	calls := 0
+	calledOnce := make(chan struct{})
+
+	done := make(chan error, 1)
+	go func() {
+		done <- retryWithPolicy(ctx, policy, func() error {
+			calls++
+			close(calledOnce)
+			return transientErr
+		})
+	}()
+	<-calledOnce
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.Equal(t, transientErr, err, "Expected the last transient error to be returned when ctx is cancelled mid-backoff")
+		assert.Equal(t, 1, calls, "Expected fn to be called once before the backoff wait was cancelled")
+	case <-time.After(time.Second):
+		t.Error("Expected retryWithPolicy to return promptly once ctx was cancelled")
+	}
+}
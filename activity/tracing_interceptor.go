@@ -0,0 +1,60 @@
+package activity
+
+import (
+	"context"
+	"sync"
+
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+// TracingInterceptor starts an OpenTracing span for each activity
+// execution, extracting the parent span from ctx when one is present, and
+// finishes it once the activity's outcome is known.
+type TracingInterceptor struct {
+	NopInterceptor
+	// Tracer is used to start spans. Defaults to opentracing.GlobalTracer()
+	// when nil.
+	Tracer opentracing.Tracer
+
+	mu    sync.Mutex
+	spans map[string]opentracing.Span
+}
+
+// BeforeExecute starts a span for the activity, as a child of any span
+// already present on ctx.
+func (i *TracingInterceptor) BeforeExecute(ctx context.Context, info ActivityInfo) {
+	var opts []opentracing.StartSpanOption
+	if parent := opentracing.SpanFromContext(ctx); parent != nil {
+		opts = append(opts, opentracing.ChildOf(parent.Context()))
+	}
+	span := i.tracer().StartSpan("activity.execute", opts...)
+	span.SetTag("workflowID", info.WorkflowID)
+	span.SetTag("activityID", info.ActivityID)
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.spans == nil {
+		i.spans = make(map[string]opentracing.Span)
+	}
+	i.spans[info.ActivityID] = span
+}
+
+// OnComplete tags the span with the activity's outcome and finishes it.
+func (i *TracingInterceptor) OnComplete(ctx context.Context, info ActivityInfo, outcome ActivityOutcome) {
+	i.mu.Lock()
+	span, ok := i.spans[info.ActivityID]
+	delete(i.spans, info.ActivityID)
+	i.mu.Unlock()
+	if !ok {
+		return
+	}
+	span.SetTag("outcome", string(outcome))
+	span.Finish()
+}
+
+func (i *TracingInterceptor) tracer() opentracing.Tracer {
+	if i.Tracer != nil {
+		return i.Tracer
+	}
+	return opentracing.GlobalTracer()
+}
@@ -0,0 +1,335 @@
+// Package activity runs user-supplied activity functions on behalf of the
+// workflow service, reporting progress, heartbeats, and completion back
+// through a workflow.Client.
+package activity
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/3dsim/workflow-goclient/encoded"
+	"github.com/3dsim/workflow-goclient/models"
+	"github.com/3dsim/workflow-goclient/workflow"
+	log "github.com/inconshreveable/log15"
+)
+
+const (
+	// defaultHeartbeatInterval is used when Worker.HeartbeatInterval is
+	// not set.
+	defaultHeartbeatInterval = 30 * time.Second
+	// defaultCancellationTimeout is used when Worker.CancellationTimeout
+	// is not set.
+	defaultCancellationTimeout = 30 * time.Second
+	// percentCompleteQueueSize bounds how many distinct percent-complete
+	// values can be queued ahead of the sender goroutine. Consecutive
+	// duplicates are already dropped before they reach the queue, so this
+	// only needs to absorb a burst of genuinely new values while a retry
+	// is in flight.
+	percentCompleteQueueSize = 16
+
+	cancelledReason     = "Cancellation requested"
+	completedMessage    = "The work completed successfully but was cancelled before the result could be reported"
+	timeoutErrorMessage = "Timed out waiting for the activity to respond to the cancellation request"
+)
+
+// ActivityFunc is a user-supplied unit of work. It should report percent
+// complete on percentCompleteChan, optionally checkpoint arbitrary resumable
+// state on detailsChan - encoded via the Worker's DataConverter and sent as
+// the next heartbeat's details, following Cadence's
+// RecordActivityHeartbeat(ctx, details...) model - and return promptly once
+// ctx is cancelled.
+type ActivityFunc func(ctx context.Context, percentCompleteChan chan<- int, detailsChan chan<- interface{}) (interface{}, error)
+
+// Worker runs a single ActivityFunc, heartbeating and reporting its outcome
+// to WorkflowClient.
+type Worker struct {
+	WorkflowClient workflow.Client
+	Logger         log.Logger
+
+	// HeartbeatInterval is how often the worker heartbeats while the
+	// activity function is running. Defaults to defaultHeartbeatInterval.
+	HeartbeatInterval time.Duration
+	// CancellationTimeout bounds how long the worker waits for the
+	// activity function to return once cancellation has been requested
+	// before reporting it cancelled anyway. Defaults to
+	// defaultCancellationTimeout.
+	CancellationTimeout time.Duration
+	// RetryPolicy governs retries of calls to WorkflowClient. Defaults to
+	// DefaultRetryPolicy() when nil.
+	RetryPolicy *RetryPolicy
+	// DataConverter encodes the activity function's result and heartbeat
+	// details before they are sent to WorkflowClient. Defaults to
+	// encoded.GetDefaultDataConverter() (JSON) when nil.
+	DataConverter encoded.DataConverter
+	// Interceptors observe the activity function's execution; each is
+	// called in order at every lifecycle hook. See ActivityInterceptor.
+	Interceptors []ActivityInterceptor
+}
+
+func (w *Worker) beforeExecute(ctx context.Context, info ActivityInfo) {
+	for _, interceptor := range w.Interceptors {
+		interceptor.BeforeExecute(ctx, info)
+	}
+}
+
+func (w *Worker) afterExecute(ctx context.Context, info ActivityInfo, result interface{}, err error) {
+	for _, interceptor := range w.Interceptors {
+		interceptor.AfterExecute(ctx, info, result, err)
+	}
+}
+
+func (w *Worker) onHeartbeat(ctx context.Context, info ActivityInfo, cancelled bool) {
+	for _, interceptor := range w.Interceptors {
+		interceptor.OnHeartbeat(ctx, info, cancelled)
+	}
+}
+
+func (w *Worker) onComplete(ctx context.Context, info ActivityInfo, outcome ActivityOutcome) {
+	for _, interceptor := range w.Interceptors {
+		interceptor.OnComplete(ctx, info, outcome)
+	}
+}
+
+func (w *Worker) dataConverter() encoded.DataConverter {
+	if w.DataConverter == nil {
+		return encoded.GetDefaultDataConverter()
+	}
+	return w.DataConverter
+}
+
+// Do runs fn to completion, heartbeating on HeartbeatInterval and reporting
+// the outcome - success, failure, or cancellation - to WorkflowClient. Calls
+// to WorkflowClient are retried per RetryPolicy and run off the select loop
+// below so a slow retry does not stall heartbeat ticks or percent-complete
+// and heartbeat-detail updates, until more than percentCompleteQueueSize
+// distinct percent-complete values are waiting to be sent, at which point the
+// loop blocks until the sender catches up; retries are bounded by ctx, so cancelling ctx
+// stops them promptly. Percent-complete updates are handed off to a single
+// sender goroutine so they always reach WorkflowClient in the order fn
+// reported them. A non-retryable error from either a heartbeat or a
+// percent-complete update cancels the context passed to fn, just as an
+// explicit cancellation request from the workflow service would.
+func (w *Worker) Do(ctx context.Context, workflowID, activityID, taskToken string, fn ActivityFunc) {
+	heartbeatInterval := w.HeartbeatInterval
+	if heartbeatInterval <= 0 {
+		heartbeatInterval = defaultHeartbeatInterval
+	}
+	cancellationTimeout := w.CancellationTimeout
+	if cancellationTimeout <= 0 {
+		cancellationTimeout = defaultCancellationTimeout
+	}
+
+	activityCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// asyncCalls tracks every percent-complete update and heartbeat call
+	// spawned below so Do doesn't return - and the caller doesn't move on
+	// as if every report had landed - while one is still in flight.
+	var asyncCalls sync.WaitGroup
+	defer asyncCalls.Wait()
+
+	info := ActivityInfo{WorkflowID: workflowID, ActivityID: activityID, TaskToken: taskToken}
+
+	percentCompleteChan := make(chan int)
+	detailsChan := make(chan interface{})
+	resultChan := make(chan activityResult, 1)
+	heartbeatResultChan := make(chan heartbeatResult, 1)
+
+	// percentCompleteQueue feeds a single dedicated sender goroutine so
+	// percent-complete updates reach WorkflowClient in the order they were
+	// reported, even though sending them is retried off the select loop.
+	percentCompleteQueue := make(chan int, percentCompleteQueueSize)
+	percentCompleteErrChan := make(chan error, 1)
+	defer close(percentCompleteQueue)
+
+	asyncCalls.Add(1)
+	go func() {
+		defer asyncCalls.Done()
+		for percentComplete := range percentCompleteQueue {
+			if err := w.updatePercentComplete(ctx, workflowID, activityID, percentComplete); err != nil {
+				select {
+				case percentCompleteErrChan <- err:
+				default:
+				}
+			}
+		}
+	}()
+
+	go func() {
+		w.beforeExecute(activityCtx, info)
+		result, err := fn(activityCtx, percentCompleteChan, detailsChan)
+		w.afterExecute(activityCtx, info, result, err)
+		resultChan <- activityResult{result: result, err: err}
+	}()
+
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+
+	lastPercentComplete := -1
+	pendingDetails := ""
+	cancelled := false
+	heartbeatInFlight := false
+
+	for {
+		select {
+		case percentComplete := <-percentCompleteChan:
+			if percentComplete == lastPercentComplete {
+				continue
+			}
+			lastPercentComplete = percentComplete
+			percentCompleteQueue <- percentComplete
+
+		case err := <-percentCompleteErrChan:
+			if !cancelled {
+				w.Logger.Error("Error updating activity percent complete, cancelling", "activityID", activityID, "error", err)
+				cancelled = true
+				cancel()
+			}
+
+		case detail := <-detailsChan:
+			data, err := w.dataConverter().ToData(detail)
+			if err != nil {
+				w.Logger.Error("Error encoding heartbeat details, dropping them", "activityID", activityID, "error", err)
+				continue
+			}
+			if string(data) == pendingDetails {
+				continue
+			}
+			pendingDetails = string(data)
+
+		case <-ticker.C:
+			if cancelled || heartbeatInFlight {
+				continue
+			}
+			heartbeatInFlight = true
+			asyncCalls.Add(1)
+			go func(details string) {
+				defer asyncCalls.Done()
+				heartbeat, err := w.heartbeat(ctx, taskToken, activityID, details)
+				heartbeatResultChan <- heartbeatResult{heartbeat: heartbeat, err: err}
+			}(pendingDetails)
+
+		case hbResult := <-heartbeatResultChan:
+			heartbeatInFlight = false
+			if hbResult.err != nil {
+				w.Logger.Error("Error heartbeating activity, cancelling", "activityID", activityID, "error", hbResult.err)
+				cancelled = true
+				cancel()
+				continue
+			}
+			if hbResult.heartbeat != nil && hbResult.heartbeat.Cancelled {
+				w.Logger.Info("Cancellation requested for activity", "activityID", activityID)
+				cancelled = true
+				cancel()
+			}
+			w.onHeartbeat(activityCtx, info, cancelled)
+
+		case result := <-resultChan:
+			if cancelled {
+				details := completedMessage
+				if result.err != nil {
+					details = result.err.Error()
+				}
+				w.completeCancelledActivity(ctx, workflowID, activityID, details)
+				w.onComplete(ctx, info, ActivityOutcomeCancelled)
+				return
+			}
+			if result.err != nil {
+				w.completeFailedActivity(ctx, workflowID, activityID, result.err.Error(), result.result)
+				w.onComplete(ctx, info, ActivityOutcomeFailed)
+				return
+			}
+			w.completeSuccessfulActivity(ctx, workflowID, activityID, result.result)
+			w.onComplete(ctx, info, ActivityOutcomeSucceeded)
+			return
+		}
+
+		if cancelled {
+			select {
+			case result := <-resultChan:
+				details := completedMessage
+				if result.err != nil {
+					details = result.err.Error()
+				}
+				w.completeCancelledActivity(ctx, workflowID, activityID, details)
+				w.onComplete(ctx, info, ActivityOutcomeCancelled)
+				return
+			case <-time.After(cancellationTimeout):
+				w.completeCancelledActivity(ctx, workflowID, activityID, timeoutErrorMessage)
+				w.onComplete(ctx, info, ActivityOutcomeCancelled)
+				return
+			}
+		}
+	}
+}
+
+type activityResult struct {
+	result interface{}
+	err    error
+}
+
+type heartbeatResult struct {
+	heartbeat *models.Heartbeat
+	err       error
+}
+
+func (w *Worker) heartbeat(ctx context.Context, taskToken, activityID, details string) (*models.Heartbeat, error) {
+	var heartbeat *models.Heartbeat
+	err := retryWithPolicy(ctx, w.RetryPolicy, func() error {
+		var callErr error
+		heartbeat, callErr = w.WorkflowClient.HeartbeatActivityWithToken(taskToken, activityID, details)
+		return callErr
+	})
+	return heartbeat, err
+}
+
+func (w *Worker) updatePercentComplete(ctx context.Context, workflowID, activityID string, percentComplete int) error {
+	err := retryWithPolicy(ctx, w.RetryPolicy, func() error {
+		return w.WorkflowClient.UpdateActivityPercentComplete(workflowID, activityID, percentComplete)
+	})
+	if err != nil {
+		w.Logger.Error("Error updating activity percent complete", "activityID", activityID, "error", err)
+	}
+	return err
+}
+
+func (w *Worker) completeSuccessfulActivity(ctx context.Context, workflowID, activityID string, result interface{}) {
+	data, err := w.dataConverter().ToData(result)
+	if err != nil {
+		w.Logger.Error("Error encoding activity result, completing without it", "activityID", activityID, "error", err)
+	}
+	err = retryWithPolicy(ctx, w.RetryPolicy, func() error {
+		return w.WorkflowClient.CompleteSuccessfulActivity(workflowID, activityID, data)
+	})
+	if err != nil {
+		w.Logger.Error("Error completing successful activity", "activityID", activityID, "error", err)
+	}
+}
+
+func (w *Worker) completeFailedActivity(ctx context.Context, workflowID, activityID, reason string, result interface{}) {
+	details := ""
+	if result != nil {
+		data, err := w.dataConverter().ToData(result)
+		if err != nil {
+			w.Logger.Error("Error encoding failed activity details, completing without them", "activityID", activityID, "error", err)
+		} else {
+			details = string(data)
+		}
+	}
+	err := retryWithPolicy(ctx, w.RetryPolicy, func() error {
+		return w.WorkflowClient.CompleteFailedActivity(workflowID, activityID, reason, details)
+	})
+	if err != nil {
+		w.Logger.Error("Error completing failed activity", "activityID", activityID, "error", err)
+	}
+}
+
+func (w *Worker) completeCancelledActivity(ctx context.Context, workflowID, activityID, details string) {
+	err := retryWithPolicy(ctx, w.RetryPolicy, func() error {
+		return w.WorkflowClient.CompleteCancelledActivity(workflowID, activityID, cancelledReason, details)
+	})
+	if err != nil {
+		w.Logger.Error("Error completing cancelled activity", "activityID", activityID, "error", err)
+	}
+}
@@ -2,6 +2,7 @@ package activity
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 	"time"
@@ -28,10 +29,11 @@ func TestDoExpectsCompleteFailedActivityCalledWhenErrorOccurs(t *testing.T) {
 	workflowID := "workflow id"
 	taskToken := "token"
 	errorReason := "Some error"
+	errorDetail := struct{ Code int }{42}
 
 	// act
-	worker.Do(context.Background(), workflowID, activityID, taskToken, func(context.Context, chan<- int) (interface{}, error) {
-		return nil, errors.New(errorReason)
+	worker.Do(context.Background(), workflowID, activityID, taskToken, func(context.Context, chan<- int, chan<- interface{}) (interface{}, error) {
+		return errorDetail, errors.New(errorReason)
 	})
 
 	// assert
@@ -40,7 +42,9 @@ func TestDoExpectsCompleteFailedActivityCalledWhenErrorOccurs(t *testing.T) {
 	assert.Equal(t, workflowID, actualWorkflowID, "Expected workflow ID passed to CompleteFailedActivity")
 	assert.Equal(t, activityID, actualActivityID, "Expected activity ID passed to CompleteFailedActivity")
 	assert.Equal(t, errorReason, actualErrorReason, "Expected error reason passed to CompleteFailedActivity")
-	assert.Equal(t, "", actualErrorDetails, "Expected error details passed to CompleteFailedActivity")
+	expectedDetails, err := json.Marshal(errorDetail)
+	assert.NoError(t, err, "Expected to be able to marshal the error detail")
+	assert.Equal(t, string(expectedDetails), actualErrorDetails, "Expected error details passed to CompleteFailedActivity to be the JSON-encoded return value")
 }
 
 func TestDoExpectsCompleteSuccessfulActivityCalledWhenNoErrorOccurs(t *testing.T) {
@@ -53,7 +57,7 @@ func TestDoExpectsCompleteSuccessfulActivityCalledWhenNoErrorOccurs(t *testing.T
 	result := struct{ SomeField string }{"the result"}
 
 	// act
-	worker.Do(context.Background(), workflowID, activityID, taskToken, func(context.Context, chan<- int) (interface{}, error) {
+	worker.Do(context.Background(), workflowID, activityID, taskToken, func(context.Context, chan<- int, chan<- interface{}) (interface{}, error) {
 		return result, nil
 	})
 
@@ -62,7 +66,9 @@ func TestDoExpectsCompleteSuccessfulActivityCalledWhenNoErrorOccurs(t *testing.T
 	actualWorkflowID, actualActivityID, actualResult := fakeWorkflowClient.CompleteSuccessfulActivityArgsForCall(0)
 	assert.Equal(t, workflowID, actualWorkflowID, "Expected workflow ID passed to CompleteSuccessfulActivity")
 	assert.Equal(t, activityID, actualActivityID, "Expected activity ID passed to CompleteSuccessfulActivity")
-	assert.Equal(t, result, actualResult, "Expected result passed to CompleteSuccessfulActivity")
+	expectedResult, err := json.Marshal(result)
+	assert.NoError(t, err, "Expected to be able to marshal the result")
+	assert.Equal(t, expectedResult, actualResult, "Expected JSON-encoded result passed to CompleteSuccessfulActivity")
 }
 
 func TestDoExpectsHeartbeatActivityWithTokenCalled(t *testing.T) {
@@ -74,7 +80,7 @@ func TestDoExpectsHeartbeatActivityWithTokenCalled(t *testing.T) {
 	taskToken := "token"
 
 	// act
-	worker.Do(context.Background(), workflowID, activityID, taskToken, func(context.Context, chan<- int) (interface{}, error) {
+	worker.Do(context.Background(), workflowID, activityID, taskToken, func(context.Context, chan<- int, chan<- interface{}) (interface{}, error) {
 		// Wait a little time for heartbeat
 		time.Sleep(10 * time.Millisecond)
 		return nil, nil
@@ -109,7 +115,7 @@ func TestDoWhenCancellationRequestedExpectsCompleteCancelledActivityCalled(t *te
 	fakeWorkflowClient.HeartbeatActivityWithTokenReturns(heartbeatToReturn, nil)
 
 	// act
-	worker.Do(context.Background(), workflowID, activityID, taskToken, func(ctx context.Context, percentCompleteChan chan<- int) (interface{}, error) {
+	worker.Do(context.Background(), workflowID, activityID, taskToken, func(ctx context.Context, percentCompleteChan chan<- int, detailsChan chan<- interface{}) (interface{}, error) {
 		select {
 		case <-ctx.Done():
 		case <-time.After(30 * time.Millisecond):
@@ -147,7 +153,7 @@ func TestDoWhenCancellationRequestedAndFunctionErrorsExpectsCompleteCancelledAct
 	fakeWorkflowClient.HeartbeatActivityWithTokenReturns(heartbeatToReturn, nil)
 
 	// act
-	worker.Do(context.Background(), workflowID, activityID, taskToken, func(ctx context.Context, percentCompleteChan chan<- int) (interface{}, error) {
+	worker.Do(context.Background(), workflowID, activityID, taskToken, func(ctx context.Context, percentCompleteChan chan<- int, detailsChan chan<- interface{}) (interface{}, error) {
 		select {
 		case <-ctx.Done():
 		case <-time.After(30 * time.Millisecond):
@@ -189,7 +195,7 @@ func TestDoWhenCancellationRequestedAndFunctionBlocksForeverExpectsCompleteCance
 	fakeWorkflowClient.HeartbeatActivityWithTokenReturns(heartbeatToReturn, nil)
 
 	// act
-	worker.Do(context.Background(), workflowID, activityID, taskToken, func(ctx context.Context, percentCompleteChan chan<- int) (interface{}, error) {
+	worker.Do(context.Background(), workflowID, activityID, taskToken, func(ctx context.Context, percentCompleteChan chan<- int, detailsChan chan<- interface{}) (interface{}, error) {
 		<-ctx.Done()
 		time.Sleep(30 * time.Millisecond)
 		return nil, errors.New("Unexpected error")
@@ -218,7 +224,7 @@ func TestDoExpectsUpdateActivityPercentCompleteCalledWhenProgressIsMade(t *testi
 	taskToken := "token"
 
 	// act
-	worker.Do(context.Background(), workflowID, activityID, taskToken, func(ctx context.Context, percentCompleteChan chan<- int) (interface{}, error) {
+	worker.Do(context.Background(), workflowID, activityID, taskToken, func(ctx context.Context, percentCompleteChan chan<- int, detailsChan chan<- interface{}) (interface{}, error) {
 		percentCompleteChan <- 30
 		percentCompleteChan <- 60
 		percentCompleteChan <- 100
@@ -233,6 +239,30 @@ func TestDoExpectsUpdateActivityPercentCompleteCalledWhenProgressIsMade(t *testi
 	assert.Equal(t, 30, actualPercentComplete, "Expected percent complete passed to UpdateActivityPercentComplete")
 }
 
+func TestDoExpectsHeartbeatDetailsToReflectTheLatestCheckpointReported(t *testing.T) {
+	// arrange
+	fakeWorkflowClient := &workflowfakes.FakeClient{}
+	worker := &Worker{WorkflowClient: fakeWorkflowClient, HeartbeatInterval: 7 * time.Millisecond, Logger: logger}
+	activityID := "activity id"
+	workflowID := "workflow id"
+	taskToken := "token"
+	checkpoint := struct{ Stage string }{"uploading"}
+
+	// act
+	worker.Do(context.Background(), workflowID, activityID, taskToken, func(ctx context.Context, percentCompleteChan chan<- int, detailsChan chan<- interface{}) (interface{}, error) {
+		detailsChan <- checkpoint
+		time.Sleep(10 * time.Millisecond)
+		return nil, nil
+	})
+
+	// assert
+	assert.Equal(t, 1, fakeWorkflowClient.HeartbeatActivityWithTokenCallCount(), "Expected to call HeartbeatActivityWithToken once")
+	_, _, actualDetails := fakeWorkflowClient.HeartbeatActivityWithTokenArgsForCall(0)
+	expectedDetails, err := json.Marshal(checkpoint)
+	assert.NoError(t, err, "Expected to be able to marshal the checkpoint")
+	assert.Equal(t, string(expectedDetails), actualDetails, "Expected heartbeat details to be the JSON-encoded checkpoint")
+}
+
 func TestDoExpectsUpdateActivityPercentCompleteCalledOnceWhenSameValuesAreSentConsecutively(t *testing.T) {
 	// arrange
 	fakeWorkflowClient := &workflowfakes.FakeClient{}
@@ -242,7 +272,7 @@ func TestDoExpectsUpdateActivityPercentCompleteCalledOnceWhenSameValuesAreSentCo
 	taskToken := "token"
 
 	// act
-	worker.Do(context.Background(), workflowID, activityID, taskToken, func(ctx context.Context, percentCompleteChan chan<- int) (interface{}, error) {
+	worker.Do(context.Background(), workflowID, activityID, taskToken, func(ctx context.Context, percentCompleteChan chan<- int, detailsChan chan<- interface{}) (interface{}, error) {
 		percentCompleteChan <- 30
 		percentCompleteChan <- 30
 		percentCompleteChan <- 30
@@ -0,0 +1,96 @@
+// Package activitytest lets an activity.ActivityFunc be unit tested without
+// wiring up a workflowfakes.FakeClient or reasoning about the goroutine and
+// heartbeat timing Worker.Do drives, mirroring Temporal's
+// TestActivityEnvironment.ExecuteActivity.
+package activitytest
+
+import (
+	"context"
+	"time"
+
+	"github.com/3dsim/workflow-goclient/activity"
+)
+
+// TestActivityEnvironment runs an activity.ActivityFunc synchronously,
+// capturing every percent-complete update and heartbeat detail it reports
+// along with its final result and error.
+type TestActivityEnvironment struct {
+	// CancelAfterHeartbeatCount cancels the context passed to the
+	// activity function once this many percent-complete updates and
+	// heartbeat details, combined, have been captured. Zero disables it.
+	CancelAfterHeartbeatCount int
+	// CancelAfterDuration cancels the context passed to the activity
+	// function after it has run for this long. Zero disables it.
+	CancelAfterDuration time.Duration
+}
+
+// TestActivityResult captures everything a TestActivityEnvironment observed
+// while running an activity function.
+type TestActivityResult struct {
+	// PercentCompletes are every value sent on percentCompleteChan, in
+	// order.
+	PercentCompletes []int
+	// Details are every value sent on detailsChan, in order.
+	Details []interface{}
+	// Result is the activity function's returned result.
+	Result interface{}
+	// Err is the activity function's returned error.
+	Err error
+}
+
+// NewTestActivityEnvironment returns a ready-to-use TestActivityEnvironment.
+func NewTestActivityEnvironment() *TestActivityEnvironment {
+	return &TestActivityEnvironment{}
+}
+
+// ExecuteActivity runs fn to completion, capturing its percent-complete
+// updates, heartbeat details, result, and error into a TestActivityResult.
+func (e *TestActivityEnvironment) ExecuteActivity(fn activity.ActivityFunc) *TestActivityResult {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if e.CancelAfterDuration > 0 {
+		var timeoutCancel context.CancelFunc
+		ctx, timeoutCancel = context.WithTimeout(ctx, e.CancelAfterDuration)
+		defer timeoutCancel()
+	}
+
+	percentCompleteChan := make(chan int)
+	detailsChan := make(chan interface{})
+	resultChan := make(chan activityFuncResult, 1)
+
+	go func() {
+		result, err := fn(ctx, percentCompleteChan, detailsChan)
+		resultChan <- activityFuncResult{result: result, err: err}
+	}()
+
+	result := &TestActivityResult{}
+	heartbeatCount := 0
+	maybeCancel := func() {
+		heartbeatCount++
+		if e.CancelAfterHeartbeatCount > 0 && heartbeatCount >= e.CancelAfterHeartbeatCount {
+			cancel()
+		}
+	}
+
+	for {
+		select {
+		case percentComplete := <-percentCompleteChan:
+			result.PercentCompletes = append(result.PercentCompletes, percentComplete)
+			maybeCancel()
+
+		case detail := <-detailsChan:
+			result.Details = append(result.Details, detail)
+			maybeCancel()
+
+		case funcResult := <-resultChan:
+			result.Result = funcResult.result
+			result.Err = funcResult.err
+			return result
+		}
+	}
+}
+
+type activityFuncResult struct {
+	result interface{}
+	err    error
+}
@@ -0,0 +1,63 @@
+package activitytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteActivityCapturesPercentCompletesDetailsAndResult(t *testing.T) {
+	// arrange
+	env := NewTestActivityEnvironment()
+
+	// act
+	result := env.ExecuteActivity(func(ctx context.Context, percentCompleteChan chan<- int, detailsChan chan<- interface{}) (interface{}, error) {
+		percentCompleteChan <- 30
+		detailsChan <- struct{ Stage string }{"uploading"}
+		percentCompleteChan <- 100
+		return "the result", nil
+	})
+
+	// assert
+	assert.Equal(t, []int{30, 100}, result.PercentCompletes, "Expected every percent complete update to be captured in order")
+	assert.Equal(t, []interface{}{struct{ Stage string }{"uploading"}}, result.Details, "Expected every heartbeat detail to be captured in order")
+	assert.Equal(t, "the result", result.Result, "Expected the activity function's result to be captured")
+	assert.NoError(t, result.Err, "Expected no error")
+}
+
+func TestExecuteActivityCapturesTheActivityFunctionsError(t *testing.T) {
+	// arrange
+	env := NewTestActivityEnvironment()
+	expectedErr := errors.New("some error")
+
+	// act
+	result := env.ExecuteActivity(func(ctx context.Context, percentCompleteChan chan<- int, detailsChan chan<- interface{}) (interface{}, error) {
+		return nil, expectedErr
+	})
+
+	// assert
+	assert.Equal(t, expectedErr, result.Err, "Expected the activity function's error to be captured")
+}
+
+func TestExecuteActivityCancelsAfterTheConfiguredHeartbeatCount(t *testing.T) {
+	// arrange
+	env := &TestActivityEnvironment{CancelAfterHeartbeatCount: 2}
+
+	// act
+	result := env.ExecuteActivity(func(ctx context.Context, percentCompleteChan chan<- int, detailsChan chan<- interface{}) (interface{}, error) {
+		percentCompleteChan <- 10
+		percentCompleteChan <- 20
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Second):
+			t.Error("Expected the context to be cancelled after 2 heartbeats")
+		}
+		return nil, ctx.Err()
+	})
+
+	// assert
+	assert.Equal(t, context.Canceled, result.Err, "Expected the activity function to observe the cancellation")
+}
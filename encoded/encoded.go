@@ -0,0 +1,35 @@
+// Package encoded defines the seam the worker uses to turn activity inputs,
+// results, and heartbeat details into bytes the workflow service can carry,
+// and to turn those bytes back into typed values for callers. It mirrors the
+// encoded package Cadence and Temporal expose for the same purpose.
+package encoded
+
+// DataConverter encodes and decodes the values that cross the boundary
+// between an activity function and the workflow service: its result, its
+// error details, and any heartbeat details it reports. The default
+// implementation is JSON; callers can supply their own, e.g. to use
+// protobuf.
+type DataConverter interface {
+	// ToData encodes one or more values into a single payload.
+	ToData(value ...interface{}) ([]byte, error)
+	// FromData decodes a payload produced by ToData back into valuePtr,
+	// in the same order they were passed to ToData.
+	FromData(data []byte, valuePtr ...interface{}) error
+}
+
+// Value is a single value encoded by a DataConverter, such as an activity's
+// heartbeat details or its result.
+type Value interface {
+	// HasValue reports whether the value carries any data.
+	HasValue() bool
+	// Get decodes the value into valuePtr.
+	Get(valuePtr interface{}) error
+}
+
+// Values is like Value but for a payload encoding more than one value.
+type Values interface {
+	// HasValues reports whether the payload carries any data.
+	HasValues() bool
+	// Get decodes the payload into valuePtrs, in encoding order.
+	Get(valuePtr ...interface{}) error
+}
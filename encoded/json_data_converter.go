@@ -0,0 +1,47 @@
+package encoded
+
+import "encoding/json"
+
+// jsonDataConverter is the default DataConverter: it encodes values as JSON,
+// wrapping more than one value in a JSON array.
+type jsonDataConverter struct{}
+
+var defaultDataConverter DataConverter = jsonDataConverter{}
+
+// GetDefaultDataConverter returns the DataConverter Worker uses when none is
+// configured.
+func GetDefaultDataConverter() DataConverter {
+	return defaultDataConverter
+}
+
+func (jsonDataConverter) ToData(values ...interface{}) ([]byte, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	if len(values) == 1 {
+		return json.Marshal(values[0])
+	}
+	return json.Marshal(values)
+}
+
+func (jsonDataConverter) FromData(data []byte, valuePtrs ...interface{}) error {
+	if len(data) == 0 || len(valuePtrs) == 0 {
+		return nil
+	}
+	if len(valuePtrs) == 1 {
+		return json.Unmarshal(data, valuePtrs[0])
+	}
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	for i, valuePtr := range valuePtrs {
+		if i >= len(raw) {
+			break
+		}
+		if err := json.Unmarshal(raw[i], valuePtr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package encoded
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONDataConverterRoundTripsASingleValue(t *testing.T) {
+	converter := GetDefaultDataConverter()
+	type progress struct {
+		Stage   string
+		Percent int
+	}
+	want := progress{Stage: "scanning", Percent: 42}
+
+	data, err := converter.ToData(want)
+	assert.NoError(t, err, "Expected ToData to succeed")
+
+	var got progress
+	err = converter.FromData(data, &got)
+	assert.NoError(t, err, "Expected FromData to succeed")
+	assert.Equal(t, want, got, "Expected decoded value to match the encoded value")
+}
+
+func TestNewValueGetIsANoOpWhenThereIsNoData(t *testing.T) {
+	value := NewValue(nil, nil)
+
+	assert.False(t, value.HasValue(), "Expected HasValue to be false for empty data")
+	var got string
+	assert.NoError(t, value.Get(&got), "Expected Get to be a no-op when there is no data")
+	assert.Equal(t, "", got, "Expected the target to be left untouched")
+}
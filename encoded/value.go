@@ -0,0 +1,55 @@
+package encoded
+
+// encodedValue is a Value backed by a payload from a DataConverter, such as
+// an activity result or a heartbeat's details.
+type encodedValue struct {
+	data          []byte
+	dataConverter DataConverter
+}
+
+// NewValue wraps data, encoded by dataConverter, so callers can decode it
+// into a typed variable with Get. A nil dataConverter uses
+// GetDefaultDataConverter.
+func NewValue(data []byte, dataConverter DataConverter) Value {
+	if dataConverter == nil {
+		dataConverter = GetDefaultDataConverter()
+	}
+	return &encodedValue{data: data, dataConverter: dataConverter}
+}
+
+func (v *encodedValue) HasValue() bool {
+	return len(v.data) > 0
+}
+
+func (v *encodedValue) Get(valuePtr interface{}) error {
+	if !v.HasValue() {
+		return nil
+	}
+	return v.dataConverter.FromData(v.data, valuePtr)
+}
+
+// encodedValues is a Values backed by a payload encoding more than one value.
+type encodedValues struct {
+	data          []byte
+	dataConverter DataConverter
+}
+
+// NewValues wraps data, encoded by dataConverter, holding more than one
+// value. A nil dataConverter uses GetDefaultDataConverter.
+func NewValues(data []byte, dataConverter DataConverter) Values {
+	if dataConverter == nil {
+		dataConverter = GetDefaultDataConverter()
+	}
+	return &encodedValues{data: data, dataConverter: dataConverter}
+}
+
+func (v *encodedValues) HasValues() bool {
+	return len(v.data) > 0
+}
+
+func (v *encodedValues) Get(valuePtrs ...interface{}) error {
+	if !v.HasValues() {
+		return nil
+	}
+	return v.dataConverter.FromData(v.data, valuePtrs...)
+}
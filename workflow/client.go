@@ -0,0 +1,35 @@
+// Package workflow provides a client for reporting activity progress and
+// completion back to the workflow service.
+package workflow
+
+import "github.com/3dsim/workflow-goclient/models"
+
+// Client talks to the workflow service on behalf of an activity worker. It is
+// the seam activity.Worker uses to heartbeat and complete activities, and is
+// mocked out in workflowfakes for tests.
+//go:generate counterfeiter . Client
+type Client interface {
+	// HeartbeatActivityWithToken reports that the activity identified by
+	// taskToken/activityID is still alive, optionally carrying progress
+	// details. The returned Heartbeat indicates whether cancellation has
+	// been requested.
+	HeartbeatActivityWithToken(taskToken, activityID, details string) (*models.Heartbeat, error)
+
+	// CompleteSuccessfulActivity reports that the activity finished
+	// successfully. result is the activity's result already encoded by the
+	// caller's DataConverter - implementations must send it as-is and must
+	// not re-marshal it.
+	CompleteSuccessfulActivity(workflowID, activityID string, result []byte) error
+
+	// CompleteFailedActivity reports that the activity failed with the
+	// given reason and details.
+	CompleteFailedActivity(workflowID, activityID, reason, details string) error
+
+	// CompleteCancelledActivity reports that the activity stopped in
+	// response to a cancellation request.
+	CompleteCancelledActivity(workflowID, activityID, reason, details string) error
+
+	// UpdateActivityPercentComplete reports the activity's current
+	// percent complete, from 0 to 100.
+	UpdateActivityPercentComplete(workflowID, activityID string, percentComplete int) error
+}